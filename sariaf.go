@@ -6,12 +6,19 @@ import (
 	"fmt"
 	"net/http"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 )
 
 // MethodAny means any http method.
 const MethodAny = "ANY"
 
+// MethodNotFound is a pseudo method used with Handle to register a 404
+// handler scoped to a path pattern rather than a dispatchable route. See
+// Router.NotFound.
+const MethodNotFound = "NOTFOUND"
+
 // ContextKeyType is the context key type.
 type ContextKeyType int
 
@@ -29,6 +36,9 @@ type (
 	//  RouterOption is the option type for router.
 	RouterOption struct {
 		Tag interface{}
+		// Name is the route name set via the Name option, used to look the
+		// route up again with Router.URL.
+		Name string
 	}
 
 	// RouterOptionFn defines the option func type to set router option.
@@ -36,14 +46,22 @@ type (
 
 	// Node represents a sub path in the router trie.
 	Node struct {
-		Path     string
-		Key      string
-		Children map[string]*Node
-		Handler  http.HandlerFunc
-		Param    string
-		Star     bool
-		Option   *RouterOption
-		Router   *Router
+		Path        string
+		Key         string
+		Children    map[string]*Node
+		Handler     http.HandlerFunc
+		Param       string
+		Star        bool
+		Option      *RouterOption
+		Router      *Router
+		Middlewares []func(http.HandlerFunc) http.HandlerFunc
+		// Regex is non-nil for a constrained param node (e.g. {id:int}); the
+		// segment must match it for this node to be taken.
+		Regex *regexp.Regexp
+		// Constrained holds this node's constrained param children, tried in
+		// insertion order after static Children and before the unconstrained
+		// "*" child.
+		Constrained []*Node
 	}
 
 	RouterContext struct {
@@ -57,15 +75,75 @@ var (
 	ErrRouterDuplicate = errors.New("duplicate router path found")
 	// ErrRouterSyntax is the root error for router pattern invalid syntax.
 	ErrRouterSyntax = errors.New("invalid router syntax found")
+	// ErrRouterInvalidConstraint is the root error for an invalid regex
+	// constraint in a router pattern, e.g. {name:regex}.
+	ErrRouterInvalidConstraint = errors.New("invalid router constraint found")
+	// ErrRouterDuplicateName is the root error for registering two routes
+	// with the same Name.
+	ErrRouterDuplicateName = errors.New("duplicate router name found")
+	// ErrRouterNameNotFound is the root error for reversing a route name
+	// that was never registered.
+	ErrRouterNameNotFound = errors.New("router name not found")
+	// ErrRouterMissingParam is the root error for reversing a route
+	// without supplying all the params its pattern needs.
+	ErrRouterMissingParam = errors.New("missing router url param")
+	// ErrRouterExtraParam is the root error for reversing a route with
+	// params its pattern does not use.
+	ErrRouterExtraParam = errors.New("unexpected router url param")
 )
 
+// constraintShorthand maps a built-in type name usable in a {name:type}
+// segment to the unanchored regex matching a single path segment of that
+// type.
+var constraintShorthand = map[string]string{
+	"int":   `[0-9]+`,
+	"alpha": `[a-zA-Z]+`,
+	"uuid":  `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`,
+}
+
 // Tag attaches a tag to router option.
 func Tag(tag interface{}) RouterOptionFn {
 	return func(o *RouterOption) { o.Tag = tag }
 }
 
-// add method adds a new path to the trie.
-func (n *Node) add(path string, handler http.HandlerFunc, r *Router, option *RouterOption) error {
+// Name attaches a name to a route, so its URL can later be reconstructed
+// with Router.URL.
+func Name(name string) RouterOptionFn {
+	return func(o *RouterOption) { o.Name = name }
+}
+
+// parseConstraint extracts the param name and compiled matcher from a
+// "{name:pattern}" or "{name:type}" segment, where type is a key of
+// constraintShorthand. ok is false if k is not of this form, in which case
+// name and re are unset.
+func parseConstraint(k string) (name string, re *regexp.Regexp, ok bool, err error) {
+	if len(k) < 3 || k[0] != '{' || k[len(k)-1] != '}' {
+		return "", nil, false, nil
+	}
+
+	inner := k[1 : len(k)-1]
+	sep := strings.IndexByte(inner, ':')
+	if sep < 0 {
+		return "", nil, false, nil
+	}
+
+	name, pattern := inner[:sep], inner[sep+1:]
+	if shorthand, isShorthand := constraintShorthand[pattern]; isShorthand {
+		pattern = shorthand
+	}
+
+	re, err = regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return "", nil, true, fmt.Errorf("router constraint %s invalid: %w", k, ErrRouterInvalidConstraint)
+	}
+
+	return name, re, true, nil
+}
+
+// add method adds a new path to the trie, returning the leaf Node created
+// or reused for it.
+func (n *Node) add(path string, handler http.HandlerFunc, r *Router, option *RouterOption,
+	middlewares []func(http.HandlerFunc) http.HandlerFunc) (*Node, error) {
 	current := n
 	trimmed := strings.TrimPrefix(path, "/")
 	slice := strings.Split(trimmed, "/")
@@ -73,16 +151,44 @@ func (n *Node) add(path string, handler http.HandlerFunc, r *Router, option *Rou
 	stars := 0
 	starPrev := false
 
+	// Validate the whole pattern, including compiling every constraint
+	// regex, before mutating any node: a bad segment later in the pattern
+	// must not leave earlier segments spliced into the trie.
 	for _, k := range slice {
 		if len(k) > 1 && k[0] == '*' {
 			stars++
 		}
 		if stars > 1 {
-			return fmt.Errorf("router pattern invalid, only one *abc allowed: %w", ErrRouterSyntax)
+			return nil, fmt.Errorf("router pattern invalid, only one *abc allowed: %w", ErrRouterSyntax)
+		}
+
+		if _, _, _, err := parseConstraint(k); err != nil {
+			return nil, err
 		}
 	}
 
 	for _, k := range slice {
+		name, re, isConstraint, _ := parseConstraint(k)
+
+		if isConstraint {
+			next := current.findConstrained(k)
+			if next == nil {
+				duplicate = false
+				next = &Node{
+					Path:     path,
+					Key:      k,
+					Children: make(map[string]*Node),
+					Param:    name,
+					Option:   option,
+					Router:   r,
+					Regex:    re,
+				}
+				current.Constrained = append(current.Constrained, next)
+			}
+			current = next
+			continue
+		}
+
 		// replace keys with pattern ":abc" to "abc" or "*abc" to "abc" for matching params.
 		param := ""
 		if len(k) > 1 && (k[0] == ':' || k[0] == '*') {
@@ -94,7 +200,7 @@ func (n *Node) add(path string, handler http.HandlerFunc, r *Router, option *Rou
 			if _, ok := current.Children[k]; ok {
 				break
 			}
-			return fmt.Errorf("router pattern %s conflicts: %w", path, ErrRouterSyntax)
+			return nil, fmt.Errorf("router pattern %s conflicts: %w", path, ErrRouterSyntax)
 		}
 
 		next, ok := current.Children[k]
@@ -117,13 +223,60 @@ func (n *Node) add(path string, handler http.HandlerFunc, r *Router, option *Rou
 	}
 
 	if duplicate {
-		return fmt.Errorf("%s: %w", path, ErrRouterDuplicate)
+		return nil, fmt.Errorf("%s: %w", path, ErrRouterDuplicate)
 	}
 
 	current.Handler = handler
+	current.Middlewares = middlewares
+	return current, nil
+}
+
+// placeholderName returns the param name of a route pattern segment (":x",
+// "*x" or "{x:...}") and whether the segment is a placeholder at all.
+func placeholderName(seg string) (string, bool) {
+	if len(seg) > 1 && (seg[0] == ':' || seg[0] == '*') {
+		return seg[1:], true
+	}
+
+	if name, _, ok, err := parseConstraint(seg); ok && err == nil {
+		return name, true
+	}
+
+	return "", false
+}
+
+// findConstrained returns the constrained child of n registered with the
+// exact raw segment key, or nil if none matches.
+func (n *Node) findConstrained(key string) *Node {
+	for _, c := range n.Constrained {
+		if c.Key == key {
+			return c
+		}
+	}
 	return nil
 }
 
+// matchChild returns the child of n that matches segment k, trying static
+// Children first, then Constrained children in insertion order, and finally
+// the unconstrained "*" param/wildcard child.
+func (n *Node) matchChild(k string) (*Node, bool) {
+	if next, ok := n.Children[k]; ok {
+		return next, true
+	}
+
+	for _, c := range n.Constrained {
+		if c.Regex.MatchString(k) {
+			return c, true
+		}
+	}
+
+	if next, ok := n.Children["*"]; ok {
+		return next, true
+	}
+
+	return nil, false
+}
+
 // find method match the request url path with a Node in trie.
 func (n *Node) find(path string) (*Node, RouterParams) {
 	params := make(RouterParams)
@@ -132,14 +285,10 @@ func (n *Node) find(path string) (*Node, RouterParams) {
 	slice := strings.Split(trimmed, "/")
 
 	for i, k := range slice {
-		var next *Node
-
-		next, ok := cur.Children[k]
+		next, ok := cur.matchChild(k)
 		if !ok {
-			if next, ok = cur.Children["*"]; !ok {
-				// return nil if no Node match the given path.
-				return nil, params
-			}
+			// return nil if no Node match the given path.
+			return nil, params
 		}
 
 		cur = next
@@ -183,14 +332,49 @@ func fromContext(ctx context.Context) *RouterContext {
 // Router is an HTTP request multiplexer. It matches the URL of each
 // incoming request against a list of registered path with their associated
 // methods and calls the handler for the given URL.
+//
+// A Router returned by Group or With is a sub-router: it shares the trees,
+// notFound and panicHandler of the router it was created from, but prefixes
+// every path it registers and carries its own middleware stack, which runs
+// after its ancestors' when a request reaches a handler registered on it.
 type Router struct {
 	trees map[string]*Node
-	// middlewares stack.
+	// middlewares stack, own to this router/group only.
 	middlewares []func(http.HandlerFunc) http.HandlerFunc
 	// notFound for when no matching route is found.
 	notFound http.HandlerFunc
 	// PanicHandler for handling panic.
 	panicHandler PanicHandlerType
+	// prefix is prepended to every path registered through this router.
+	prefix string
+	// parent is the router this one was created from via Group/With, or nil
+	// for the root router.
+	parent *Router
+	// groupOptionFns are applied, before any per-call options, to every
+	// route registered through this router.
+	groupOptionFns []RouterOptionFn
+	// redirectTrailingSlash, when true, redirects a request to the
+	// alternate trailing-slash form of its path if that form matches a
+	// registered route.
+	redirectTrailingSlash bool
+	// redirectFixedPath, when true, redirects a request to its
+	// CleanPath-corrected form if that form matches a registered route.
+	redirectFixedPath bool
+	// handleMethodNotAllowed, when true, responds 405 instead of 404 when
+	// the path matches a route registered under a different method.
+	handleMethodNotAllowed bool
+	// methodNotAllowed is called to reply when handleMethodNotAllowed is
+	// true and the path matches another method.
+	methodNotAllowed http.HandlerFunc
+	// globalOPTIONS, if set, is called for OPTIONS requests that have no
+	// explicit route but whose path matches a route of another method,
+	// after the Allow header has been set.
+	globalOPTIONS http.HandlerFunc
+	// names maps a route Name to its leaf Node, for reversal with URL.
+	names map[string]*Node
+	// notFoundTree holds routes registered with MethodNotFound/NotFound,
+	// searched on a miss before falling back to notFound.
+	notFoundTree *Node
 }
 
 // NotFound replies to the request with an HTTP 404 not found error.
@@ -209,18 +393,73 @@ func PanicHandler(w http.ResponseWriter, r *http.Request, err interface{}) {
 	_, _ = fmt.Fprint(w, "Internal Server Error:", err)
 }
 
+// MethodNotAllowed replies to the request with an HTTP 405 method not
+// allowed error.
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusMethodNotAllowed)
+	_, _ = fmt.Fprint(w, "Method Not Allowed")
+}
+
 // New returns a new Router.
 func New() *Router {
 	return &Router{
-		trees:        make(map[string]*Node),
-		notFound:     NotFound,
-		panicHandler: PanicHandler,
+		trees:            make(map[string]*Node),
+		notFound:         NotFound,
+		panicHandler:     PanicHandler,
+		methodNotAllowed: MethodNotAllowed,
 	}
 }
 
 // Noop replies to the request with nothing.
 func Noop(http.ResponseWriter, *http.Request) {}
 
+// root returns the ultimate router a chain of Group/With calls descends
+// from, i.e. the router holding the shared trees, notFound and panicHandler.
+func (r *Router) root() *Router {
+	root := r
+	for root.parent != nil {
+		root = root.parent
+	}
+	return root
+}
+
+// resolvedMiddlewares returns the full middleware chain that applies to
+// routes registered through r: r's own middlewares first, followed by its
+// ancestors', so that once wrapped around a handler the root's middlewares
+// end up outermost and run first, with r's own running closest to the
+// handler.
+func (r *Router) resolvedMiddlewares() []func(http.HandlerFunc) http.HandlerFunc {
+	chain := append([]func(http.HandlerFunc) http.HandlerFunc{}, r.middlewares...)
+	if r.parent != nil {
+		chain = append(chain, r.parent.resolvedMiddlewares()...)
+	}
+	return chain
+}
+
+// Group returns a sub-router that shares r's trees, notFound and
+// panicHandler. Every path registered through the returned Router is
+// prefixed with prefix, and optionFns are applied as default options to its
+// routes, ahead of any options passed to the specific Handle/GET/... call.
+// Use on the returned Router only affects handlers registered on it or its
+// descendants.
+func (r *Router) Group(prefix string, optionFns ...RouterOptionFn) *Router {
+	return &Router{
+		prefix:         r.prefix + prefix,
+		parent:         r,
+		groupOptionFns: append(append([]RouterOptionFn{}, r.groupOptionFns...), optionFns...),
+	}
+}
+
+// With returns a sub-router, as Group does, with middlewares appended to the
+// chain used for routes registered through it.
+func (r *Router) With(middlewares ...func(http.HandlerFunc) http.HandlerFunc) *Router {
+	g := r.Group("")
+	g.middlewares = append([]func(http.HandlerFunc) http.HandlerFunc{}, middlewares...)
+	return g
+}
+
 // ServeHTTP matches r.URL.Path with a stored route and calls handler for found Node.
 func (n *Node) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	defer func() {
@@ -231,7 +470,7 @@ func (n *Node) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 	// call the middlewares on handler
 	h := n.Handler
-	for _, middle := range n.Router.middlewares {
+	for _, middle := range n.Middlewares {
 		h = middle(h)
 	}
 
@@ -239,15 +478,169 @@ func (n *Node) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	h(w, req)
 }
 
+// CleanPath returns the canonical form of path: runs of "/" are collapsed
+// to one, "." segments are dropped, ".." segments are resolved against the
+// previous segment (or dropped outright at the root), and a trailing slash
+// is preserved if path had one. CleanPath operates on the raw path and does
+// not decode percent-escapes. An empty path cleans to "/".
+func CleanPath(p string) string {
+	if p == "" {
+		return "/"
+	}
+
+	trailingSlash := len(p) > 1 && p[len(p)-1] == '/'
+
+	segments := strings.Split(p, "/")
+	cleaned := make([]string, 0, len(segments))
+
+	for _, s := range segments {
+		switch s {
+		case "", ".":
+			// drop empty segments (from collapsed "//") and "." segments.
+		case "..":
+			if len(cleaned) > 0 {
+				cleaned = cleaned[:len(cleaned)-1]
+			}
+		default:
+			cleaned = append(cleaned, s)
+		}
+	}
+
+	result := "/" + strings.Join(cleaned, "/")
+	if trailingSlash && result != "/" {
+		result += "/"
+	}
+
+	return result
+}
+
+// redirectCode returns the HTTP status code used for automatic redirects:
+// 301 for GET/HEAD so caches and clients update bookmarks, 308 otherwise so
+// the method and body are preserved by the client.
+func redirectCode(method string) int {
+	if method == http.MethodGet || method == http.MethodHead {
+		return http.StatusMovedPermanently
+	}
+	return http.StatusPermanentRedirect
+}
+
+// redirectPath looks for a corrected form of path that does match a
+// registered route, honoring the redirectTrailingSlash and redirectFixedPath
+// settings. It
+// returns the corrected path and whether one was found.
+func (r *Router) redirectPath(method, path string) (string, bool) {
+	if r.redirectTrailingSlash {
+		if alt, ok := r.toggleTrailingSlash(method, path); ok {
+			return alt, true
+		}
+	}
+
+	if r.redirectFixedPath {
+		if cleaned := CleanPath(path); cleaned != path {
+			if node, _ := r.Search(method, cleaned); node != nil {
+				return cleaned, true
+			}
+
+			if r.redirectTrailingSlash {
+				if alt, ok := r.toggleTrailingSlash(method, cleaned); ok {
+					return alt, true
+				}
+			}
+		}
+	}
+
+	return "", false
+}
+
+// toggleTrailingSlash searches for the alternate trailing-slash form of
+// path, returning it and true if it matches a registered route.
+func (r *Router) toggleTrailingSlash(method, path string) (string, bool) {
+	var alt string
+	if len(path) > 1 && path[len(path)-1] == '/' {
+		alt = path[:len(path)-1]
+	} else {
+		alt = path + "/"
+	}
+
+	if node, _ := r.Search(method, alt); node != nil {
+		return alt, true
+	}
+	return "", false
+}
+
+// allowedMethods returns, as a sorted comma-separated list suitable for an
+// Allow header, the HTTP methods other than MethodAny that have a route
+// matching path, plus OPTIONS. It returns "" if no method matches.
+func (r *Router) allowedMethods(path string) string {
+	methods := make([]string, 0, len(r.trees))
+	for method, tree := range r.trees {
+		if method == MethodAny {
+			continue
+		}
+		if node, _ := tree.find(path); node != nil {
+			methods = append(methods, method)
+		}
+	}
+
+	if len(methods) == 0 {
+		return ""
+	}
+
+	methods = append(methods, http.MethodOptions)
+	sort.Strings(methods)
+	return strings.Join(methods, ", ")
+}
+
 // ServeHTTP matches r.URL.Path with a stored route and calls handler for found Node.
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	// check if there is a trie for the request method.
 	node, params := r.Search(req.Method, req.URL.Path)
 	if node == nil {
-		r.notFound(w, req)
+		root := r.root()
+
+		if req.Method == http.MethodOptions {
+			if allow := root.allowedMethods(req.URL.Path); allow != "" {
+				w.Header().Set("Allow", allow)
+				if root.globalOPTIONS != nil {
+					root.globalOPTIONS(w, req)
+				}
+				return
+			}
+		} else if root.handleMethodNotAllowed {
+			if allow := root.allowedMethods(req.URL.Path); allow != "" {
+				w.Header().Set("Allow", allow)
+				root.methodNotAllowed(w, req)
+				return
+			}
+		}
+
+		if root.redirectTrailingSlash || root.redirectFixedPath {
+			if location, ok := root.redirectPath(req.Method, req.URL.Path); ok {
+				if req.URL.RawQuery != "" {
+					location += "?" + req.URL.RawQuery
+				}
+				http.Redirect(w, req, location, redirectCode(req.Method))
+				return
+			}
+		}
+
+		if root.notFoundTree != nil {
+			if nfNode, nfParams := root.notFoundTree.find(req.URL.Path); nfNode != nil {
+				dispatch(nfNode, nfParams, w, req)
+				return
+			}
+		}
+
+		root.notFound(w, req)
 		return
 	}
 
+	dispatch(node, params, w, req)
+}
+
+// dispatch carries params and the matched node's Option into the request
+// context and invokes the node's handler chain.
+func dispatch(node *Node, params RouterParams, w http.ResponseWriter, req *http.Request) {
 	ctx := newContext(req.Context(), &RouterContext{
 		Params: params,
 		Option: node.Option,
@@ -258,10 +651,12 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 // Search searches the node for specified http method and http request url path.
 func (r *Router) Search(method, path string) (*Node, RouterParams) {
+	root := r.root()
+
 	// check if there is a trie for the request method.
-	t, ok := r.trees[method]
+	t, ok := root.trees[method]
 	if !ok && method != MethodAny {
-		t, ok = r.trees[MethodAny]
+		t, ok = root.trees[MethodAny]
 	}
 
 	if !ok {
@@ -274,34 +669,115 @@ func (r *Router) Search(method, path string) (*Node, RouterParams) {
 	}
 
 	// try any
-	if t, ok = r.trees[MethodAny]; !ok {
+	if t, ok = root.trees[MethodAny]; !ok {
 		return nil, nil
 	}
 
 	return t.find(path)
 }
 
-// Handle registers a new path with the given path and method.
+// Handle registers a new path with the given path and method. If r is a
+// sub-router returned by Group or With, path is prefixed and the route is
+// stored in the shared trees of r's root router.
 func (r *Router) Handle(method string, path string, handler http.HandlerFunc, optionFns ...RouterOptionFn) error {
 	if handler == nil {
 		handler = Noop
 	}
 
-	// check if for given method there is not any tie create a new one.
-	if _, ok := r.trees[method]; !ok {
-		r.trees[method] = &Node{
-			Path:     "/",
-			Children: make(map[string]*Node),
-			Router:   r,
+	root := r.root()
+
+	var tree *Node
+	if method == MethodNotFound {
+		if root.notFoundTree == nil {
+			root.notFoundTree = &Node{Path: "/", Children: make(map[string]*Node), Router: root}
+		}
+		tree = root.notFoundTree
+	} else {
+		// check if for given method there is not any tie create a new one.
+		if _, ok := root.trees[method]; !ok {
+			root.trees[method] = &Node{
+				Path:     "/",
+				Children: make(map[string]*Node),
+				Router:   root,
+			}
 		}
+		tree = root.trees[method]
 	}
 
 	routerOption := &RouterOption{}
+	for _, f := range r.groupOptionFns {
+		f(routerOption)
+	}
 	for _, f := range optionFns {
 		f(routerOption)
 	}
 
-	return r.trees[method].add(path, handler, r, routerOption)
+	// Reject a duplicate Name before adding the route to the trie: an error return
+	// must mean the registration did not take effect.
+	if routerOption.Name != "" {
+		if _, exists := root.names[routerOption.Name]; exists {
+			return fmt.Errorf("%s: %w", routerOption.Name, ErrRouterDuplicateName)
+		}
+	}
+
+	node, err := tree.add(r.prefix+path, handler, root, routerOption, r.resolvedMiddlewares())
+	if err != nil {
+		return err
+	}
+
+	if routerOption.Name != "" {
+		if root.names == nil {
+			root.names = make(map[string]*Node)
+		}
+		root.names[routerOption.Name] = node
+	}
+
+	return nil
+}
+
+// URL reconstructs the URL of the route registered with Name(name),
+// substituting each ":x"/"*x"/"{x:...}" placeholder in its pattern with the
+// corresponding value from params, given as alternating key/value pairs.
+func (r *Router) URL(name string, params ...string) (string, error) {
+	root := r.root()
+
+	node, ok := root.names[name]
+	if !ok {
+		return "", fmt.Errorf("%s: %w", name, ErrRouterNameNotFound)
+	}
+
+	if len(params)%2 != 0 {
+		return "", fmt.Errorf("%s: params must be key/value pairs: %w", name, ErrRouterMissingParam)
+	}
+
+	values := make(map[string]string, len(params)/2)
+	for i := 0; i < len(params); i += 2 {
+		values[params[i]] = params[i+1]
+	}
+
+	segments := strings.Split(strings.TrimPrefix(node.Path, "/"), "/")
+	used := make(map[string]bool, len(values))
+
+	for i, seg := range segments {
+		paramName, isPlaceholder := placeholderName(seg)
+		if !isPlaceholder {
+			continue
+		}
+
+		value, ok := values[paramName]
+		if !ok {
+			return "", fmt.Errorf("%s: missing param %q: %w", name, paramName, ErrRouterMissingParam)
+		}
+
+		segments[i] = value
+		used[paramName] = true
+	}
+
+	if len(used) != len(values) {
+		return "", fmt.Errorf("%s: %w", name, ErrRouterExtraParam)
+	}
+
+	return "/" + strings.Join(segments, "/"), nil
 }
 
 // Params returns params stored in the request.
@@ -358,10 +834,56 @@ func (r *Router) HEAD(path string, handle http.HandlerFunc, optionFns ...RouterO
 
 // SetNotFound will register a handler for when no matching route is found
 func (r *Router) SetNotFound(handle http.HandlerFunc) {
-	r.notFound = handle
+	r.root().notFound = handle
+}
+
+// NotFound registers handle as the 404 response for requests whose path
+// matches pattern, taking priority over the router's global not-found
+// handler set via SetNotFound. It is a convenience for
+// r.Handle(MethodNotFound, pattern, handle, optionFns...).
+func (r *Router) NotFound(pattern string, handle http.HandlerFunc, optionFns ...RouterOptionFn) error {
+	return r.Handle(MethodNotFound, pattern, handle, optionFns...)
 }
 
 // SetPanicHandler will register a handler for handling panics
 func (r *Router) SetPanicHandler(handle PanicHandlerType) {
-	r.panicHandler = handle
+	r.root().panicHandler = handle
+}
+
+// SetRedirectTrailingSlash enables or disables redirecting a request to the
+// alternate trailing-slash form of its path (e.g. /foo to /foo/) when the
+// original path does not match any route but the alternate does.
+func (r *Router) SetRedirectTrailingSlash(enabled bool) {
+	r.root().redirectTrailingSlash = enabled
+}
+
+// SetRedirectFixedPath enables or disables redirecting a request to its
+// CleanPath-corrected form when the original path does not match any route
+// but the cleaned path does.
+func (r *Router) SetRedirectFixedPath(enabled bool) {
+	r.root().redirectFixedPath = enabled
+}
+
+// SetHandleMethodNotAllowed enables or disables responding 405 Method Not
+// Allowed, with an Allow header listing the methods that do match, when the
+// path matches a route registered under a different method.
+func (r *Router) SetHandleMethodNotAllowed(enabled bool) {
+	r.root().handleMethodNotAllowed = enabled
+}
+
+// SetMethodNotAllowed will register a handler for when 405 handling is
+// enabled via SetHandleMethodNotAllowed and the path matches another method.
+func (r *Router) SetMethodNotAllowed(handle http.HandlerFunc) {
+	r.root().methodNotAllowed = handle
+}
+
+// SetGlobalOPTIONS registers a handler called for OPTIONS requests that have
+// no explicit route registered for their path, after the Allow header has
+// been set to the methods that do match. This lets an application inject
+// CORS preflight logic uniformly across all routes. If unset, sariaf only
+// sets the Allow header and replies with an empty 200 OK body. An OPTIONS
+// request whose path matches no route of any method gets the not-found
+// response instead, and the handler is not called.
+func (r *Router) SetGlobalOPTIONS(handle http.HandlerFunc) {
+	r.root().globalOPTIONS = handle
 }
@@ -73,6 +73,287 @@ func TestExample(t *testing.T) {
 	assertGet(t, http.MethodGet, base+"/abc", 404, "Not Found\n")
 }
 
+func TestGroup(t *testing.T) {
+	r := sariaf.New()
+
+	var order []string
+	mw := func(name string) func(http.HandlerFunc) http.HandlerFunc {
+		return func(next http.HandlerFunc) http.HandlerFunc {
+			return func(w http.ResponseWriter, req *http.Request) {
+				order = append(order, name)
+				next(w, req)
+			}
+		}
+	}
+
+	r.Use(mw("root"))
+
+	api := r.Group("/api")
+	api.Use(mw("api"))
+
+	v1 := api.Group("/v1")
+	v1.Use(mw("v1"))
+
+	assert.Nil(t, v1.GET("/posts", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("v1 posts"))
+	}))
+
+	assert.Nil(t, api.GET("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("api health"))
+	}))
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/posts", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, "v1 posts", rec.Body.String())
+	assert.Equal(t, []string{"root", "api", "v1"}, order)
+
+	order = nil
+	req, _ = http.NewRequest(http.MethodGet, "/api/health", nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, "api health", rec.Body.String())
+	assert.Equal(t, []string{"root", "api"}, order)
+}
+
+func TestWith(t *testing.T) {
+	r := sariaf.New()
+	called := false
+
+	g := r.With(func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			called = true
+			next(w, req)
+		}
+	})
+
+	assert.Nil(t, g.GET("/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("pong"))
+	}))
+	assert.Nil(t, r.GET("/other", nil))
+
+	req, _ := http.NewRequest(http.MethodGet, "/ping", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, "pong", rec.Body.String())
+	assert.True(t, called)
+
+	called = false
+	req, _ = http.NewRequest(http.MethodGet, "/other", nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.False(t, called)
+}
+
+func TestCleanPath(t *testing.T) {
+	cases := map[string]string{
+		"":           "/",
+		"/":          "/",
+		"//":         "/",
+		"/a/b":       "/a/b",
+		"/a//b":      "/a/b",
+		"/a/./b":     "/a/b",
+		"/a/b/..":    "/a",
+		"/a/../../b": "/b",
+		"/a/b/":      "/a/b/",
+		"/a//b/..//": "/a/",
+		"/../../":    "/",
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, sariaf.CleanPath(in), "CleanPath(%q)", in)
+	}
+}
+
+func TestRedirectTrailingSlash(t *testing.T) {
+	r := sariaf.New()
+	r.SetRedirectTrailingSlash(true)
+	assert.Nil(t, r.GET("/posts/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("posts"))
+	}))
+	assert.Nil(t, r.POST("/posts/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("posts"))
+	}))
+
+	req, _ := http.NewRequest(http.MethodGet, "/posts", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "/posts/", rec.Header().Get("Location"))
+
+	req, _ = http.NewRequest(http.MethodPost, "/posts", nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusPermanentRedirect, rec.Code)
+}
+
+func TestRedirectPreservesQuery(t *testing.T) {
+	r := sariaf.New()
+	r.SetRedirectTrailingSlash(true)
+	assert.Nil(t, r.GET("/posts/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("posts"))
+	}))
+
+	req, _ := http.NewRequest(http.MethodGet, "/posts?page=2", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "/posts/?page=2", rec.Header().Get("Location"))
+}
+
+func TestRedirectFixedPath(t *testing.T) {
+	r := sariaf.New()
+	r.SetRedirectFixedPath(true)
+	assert.Nil(t, r.GET("/posts/123", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("post"))
+	}))
+
+	req, _ := http.NewRequest(http.MethodGet, "/posts//123", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "/posts/123", rec.Header().Get("Location"))
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	r := sariaf.New()
+	r.SetHandleMethodNotAllowed(true)
+	assert.Nil(t, r.GET("/posts", nil))
+	assert.Nil(t, r.POST("/posts", nil))
+
+	req, _ := http.NewRequest(http.MethodDelete, "/posts", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "GET, OPTIONS, POST", rec.Header().Get("Allow"))
+
+	req, _ = http.NewRequest(http.MethodDelete, "/missing", nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestGlobalOPTIONS(t *testing.T) {
+	r := sariaf.New()
+	assert.Nil(t, r.GET("/posts", nil))
+	assert.Nil(t, r.POST("/posts", nil))
+	r.SetGlobalOPTIONS(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	req, _ := http.NewRequest(http.MethodOptions, "/posts", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "GET, OPTIONS, POST", rec.Header().Get("Allow"))
+
+	req, _ = http.NewRequest(http.MethodOptions, "/missing", nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestConstraint(t *testing.T) {
+	r := sariaf.New()
+	assert.Nil(t, r.GET("/users/{id:int}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("id:" + sariaf.Param(r, "id")))
+	}))
+	assert.Nil(t, r.GET("/users/{name:[a-z]+}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("name:" + sariaf.Param(r, "name")))
+	}))
+
+	req, _ := http.NewRequest(http.MethodGet, "/users/123", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, "id:123", rec.Body.String())
+
+	req, _ = http.NewRequest(http.MethodGet, "/users/bob", nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, "name:bob", rec.Body.String())
+
+	req, _ = http.NewRequest(http.MethodGet, "/users/123abc", nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestConstraintInvalidRegex(t *testing.T) {
+	r := sariaf.New()
+	assert.True(t, errors.Is(r.GET("/users/{id:(}", nil), sariaf.ErrRouterInvalidConstraint))
+}
+
+func TestConstraintInvalidRegexDoesNotMutateTrie(t *testing.T) {
+	r := sariaf.New()
+	assert.True(t, errors.Is(r.GET("/users/{id:(}", nil), sariaf.ErrRouterInvalidConstraint))
+
+	assert.Nil(t, r.GET("/users", nil))
+}
+
+func TestURL(t *testing.T) {
+	r := sariaf.New()
+	assert.Nil(t, r.GET("/posts/:id/comments/*rest", nil, sariaf.Name("post-comments")))
+	assert.Nil(t, r.GET("/posts/{id:int}", nil, sariaf.Name("post")))
+
+	url, err := r.URL("post", "id", "123")
+	assert.Nil(t, err)
+	assert.Equal(t, "/posts/123", url)
+
+	url, err = r.URL("post-comments", "id", "123", "rest", "a/b")
+	assert.Nil(t, err)
+	assert.Equal(t, "/posts/123/comments/a/b", url)
+
+	_, err = r.URL("post", "id", "123", "extra", "x")
+	assert.True(t, errors.Is(err, sariaf.ErrRouterExtraParam))
+
+	_, err = r.URL("post")
+	assert.True(t, errors.Is(err, sariaf.ErrRouterMissingParam))
+
+	_, err = r.URL("missing", "id", "1")
+	assert.True(t, errors.Is(err, sariaf.ErrRouterNameNotFound))
+}
+
+func TestNameDuplicate(t *testing.T) {
+	r := sariaf.New()
+	assert.Nil(t, r.GET("/posts", nil, sariaf.Name("posts")))
+	assert.True(t, errors.Is(r.GET("/other", nil, sariaf.Name("posts")), sariaf.ErrRouterDuplicateName))
+}
+
+func TestNameDuplicateDoesNotRegisterRoute(t *testing.T) {
+	r := sariaf.New()
+	assert.Nil(t, r.GET("/a", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("a")) }, sariaf.Name("x")))
+	assert.True(t, errors.Is(
+		r.GET("/b", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("b")) }, sariaf.Name("x")),
+		sariaf.ErrRouterDuplicateName,
+	))
+
+	req, _ := http.NewRequest(http.MethodGet, "/b", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestScopedNotFound(t *testing.T) {
+	r := sariaf.New()
+	r.SetNotFound(func(w http.ResponseWriter, r *http.Request) { http.Error(w, "html not found", 404) })
+
+	assert.Nil(t, r.NotFound("/api/*rest", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(404)
+		w.Write([]byte(`{"rest":"` + sariaf.Param(r, "rest") + `"}`))
+	}))
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/missing", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, 404, rec.Code)
+	assert.Equal(t, `{"rest":"/missing"}`, rec.Body.String())
+
+	req, _ = http.NewRequest(http.MethodGet, "/other", nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, "html not found\n", rec.Body.String())
+}
+
 func Rest(method, url string) (resp *http.Response, err error) {
 	req, err := http.NewRequest(method, url, nil)
 	if err != nil {